@@ -3,19 +3,51 @@ package main
 import (
 	"context"
 	"log"
+	"net"
 	"os"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+
 	api "github.com/manis005/kart-challenge/api"
 	"github.com/manis005/kart-challenge/internal/coupons"
+	"github.com/manis005/kart-challenge/internal/grpcserver"
+	"github.com/manis005/kart-challenge/internal/kartpb"
 	"github.com/manis005/kart-challenge/internal/server"
 	"github.com/manis005/kart-challenge/internal/store"
+	"github.com/manis005/kart-challenge/internal/store/postgres"
 )
 
+// newStore selects the storage backend via KART_STORE=memory|postgres
+// (default memory). The postgres backend reads its DSN from KART_POSTGRES_DSN.
+func newStore(ctx context.Context) (store.Store, error) {
+	switch backend := os.Getenv("KART_STORE"); backend {
+	case "", "memory":
+		st := store.NewInMemoryStore()
+		st.AddProduct(store.Product{ID: "10", Name: "Chicken Waffle", Price: 299.99, Category: "Waffle"})
+		st.AddProduct(store.Product{ID: "11", Name: "Veg Burger", Price: 149.50, Category: "Burger"})
+		return st, nil
+	case "postgres":
+		dsn := os.Getenv("KART_POSTGRES_DSN")
+		if dsn == "" {
+			log.Fatalf("KART_POSTGRES_DSN must be set when KART_STORE=postgres")
+		}
+		return postgres.Open(ctx, dsn)
+	default:
+		log.Fatalf("unknown KART_STORE backend: %q (want memory or postgres)", backend)
+		return nil, nil
+	}
+}
+
 func main() {
-	st := store.NewInMemoryStore()
-	st.AddProduct(store.Product{ID: "10", Name: "Chicken Waffle", Price: 299.99, Category: "Waffle"})
-	st.AddProduct(store.Product{ID: "11", Name: "Veg Burger", Price: 149.50, Category: "Burger"})
+	ctx := context.Background()
+
+	st, err := newStore(ctx)
+	if err != nil {
+		log.Fatalf("failed to init store: %v", err)
+	}
 
 	couponFiles := []string{
 		"data/couponbase1.gz",
@@ -42,15 +74,40 @@ func main() {
 	r := gin.Default()
 
 	api.RegisterHandlers(r, svc)
+	svc.RegisterLifecycleRoutes(r)
+	svc.RegisterDebugRoutes(r)
 
 	r.GET("/health", func(c *gin.Context) {
 		c.String(200, "ok")
 	})
 
-	port := ":8080"
-	log.Printf("listening on %s", port)
+	r.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
+	httpPort := ":8080"
+	grpcPort := ":9090"
+
+	grpcSrv := grpc.NewServer()
+	grpcSvc := grpcserver.NewServer(st, mgr, svc.APIKey)
+	kartpb.RegisterProductServiceServer(grpcSrv, grpcSvc)
+	kartpb.RegisterOrderServiceServer(grpcSrv, grpcSvc)
+
+	var g errgroup.Group
+
+	g.Go(func() error {
+		log.Printf("listening on %s (http)", httpPort)
+		return r.Run(httpPort)
+	})
+
+	g.Go(func() error {
+		lis, err := net.Listen("tcp", grpcPort)
+		if err != nil {
+			return err
+		}
+		log.Printf("listening on %s (grpc)", grpcPort)
+		return grpcSrv.Serve(lis)
+	})
 
-	if err := r.Run(port); err != nil {
+	if err := g.Wait(); err != nil {
 		log.Fatalf("server failed: %v", err)
 	}
 
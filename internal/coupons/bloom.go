@@ -0,0 +1,73 @@
+package coupons
+
+import (
+	"math"
+
+	"github.com/cespare/xxhash/v2"
+)
+
+// targetFPR is the false-positive rate each per-file Bloom filter is sized
+// for. At ~0.1% FPR, IsValidPromo can reject the overwhelming majority of
+// unknown codes without touching the shard map (or RocksDB).
+const targetFPR = 0.001
+
+// bloomFilter is a fixed-size bitset Bloom filter. Membership of a single
+// code across k bit positions is tested/set using the Kirsch-Mitzenmacher
+// double-hashing trick: index_i = (h1 + i*h2) mod m, synthesizing k hash
+// functions from two independent 64-bit xxhash values instead of running k
+// real hash functions.
+type bloomFilter struct {
+	bits []uint64
+	m    uint64
+	k    uint64
+}
+
+// newBloomFilter sizes a filter for n expected unique keys at targetFPR:
+// m = -n*ln(p)/(ln2)^2 bits, k = (m/n)*ln2 hash functions.
+func newBloomFilter(n int) *bloomFilter {
+	if n <= 0 {
+		n = 1
+	}
+	m := uint64(math.Ceil(-float64(n) * math.Log(targetFPR) / (math.Ln2 * math.Ln2)))
+	if m == 0 {
+		m = 1
+	}
+	k := uint64(math.Round(float64(m) / float64(n) * math.Ln2))
+	if k == 0 {
+		k = 1
+	}
+	words := (m + 63) / 64
+	return &bloomFilter{bits: make([]uint64, words), m: m, k: k}
+}
+
+// hashPair derives two independent 64-bit hashes for code by salting xxhash,
+// which seeds the Kirsch-Mitzenmacher index synthesis below.
+func hashPair(code string) (uint64, uint64) {
+	h1 := xxhash.Sum64String(code)
+	h2 := xxhash.Sum64String(code + "\x00bloom")
+	if h2 == 0 {
+		h2 = 1 // avoid degenerating to a single hash function
+	}
+	return h1, h2
+}
+
+func (b *bloomFilter) add(code string) {
+	h1, h2 := hashPair(code)
+	for i := uint64(0); i < b.k; i++ {
+		idx := (h1 + i*h2) % b.m
+		b.bits[idx/64] |= 1 << (idx % 64)
+	}
+}
+
+// mayContain reports whether code is possibly present. False means
+// definitely absent; true means present or a false positive at ~targetFPR.
+func (b *bloomFilter) mayContain(code string) bool {
+	h1, h2 := hashPair(code)
+	for i := uint64(0); i < b.k; i++ {
+		idx := (h1 + i*h2) % b.m
+		if b.bits[idx/64]&(1<<(idx%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,86 @@
+package coupons
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestBloomFilterNoFalseNegatives is the property IsValidPromo's fast path
+// depends on: mayContain must never say "definitely absent" for a code that
+// was actually added, or IsValidPromo would wrongly reject a valid coupon
+// without ever checking the shard map.
+func TestBloomFilterNoFalseNegatives(t *testing.T) {
+	const n = 5000
+	bf := newBloomFilter(n)
+	codes := make([]string, n)
+	for i := range codes {
+		codes[i] = fmt.Sprintf("CODE%05d", i)
+		bf.add(codes[i])
+	}
+
+	for _, c := range codes {
+		if !bf.mayContain(c) {
+			t.Fatalf("mayContain(%q) = false, want true (false negative)", c)
+		}
+	}
+}
+
+// TestBloomFilterFalsePositiveRateNearTarget sizes a filter for n keys,
+// adds only those, then checks codes known never to have been added: the
+// observed false-positive rate should land in the same order of magnitude
+// as targetFPR. This doesn't pin an exact rate (that depends on the hash),
+// just guards against a sizing bug in newBloomFilter (e.g. wrong m/k
+// formula) blowing the rate up by an order of magnitude or more.
+func TestBloomFilterFalsePositiveRateNearTarget(t *testing.T) {
+	const n = 5000
+	bf := newBloomFilter(n)
+	for i := 0; i < n; i++ {
+		bf.add(fmt.Sprintf("CODE%05d", i))
+	}
+
+	const trials = 20000
+	falsePositives := 0
+	for i := 0; i < trials; i++ {
+		code := fmt.Sprintf("ABSENT%06d", i)
+		if bf.mayContain(code) {
+			falsePositives++
+		}
+	}
+
+	rate := float64(falsePositives) / trials
+	// Allow a generous margin (10x target) since this is a statistical
+	// property, not an exact one - the point is catching a gross sizing
+	// error, not asserting a precise FPR.
+	if maxRate := targetFPR * 10; rate > maxRate {
+		t.Fatalf("observed false-positive rate %.5f exceeds %.5f (10x targetFPR %.5f)", rate, maxRate, targetFPR)
+	}
+}
+
+// TestBloomFilterSizingMonotonic checks newBloomFilter grows m (and thus
+// the underlying bitset) as n grows, and never produces a degenerate
+// zero-sized filter.
+func TestBloomFilterSizingMonotonic(t *testing.T) {
+	small := newBloomFilter(10)
+	large := newBloomFilter(100_000)
+
+	if small.m == 0 || small.k == 0 {
+		t.Fatalf("newBloomFilter(10) produced degenerate filter: m=%d k=%d", small.m, small.k)
+	}
+	if large.m <= small.m {
+		t.Fatalf("newBloomFilter(100000).m = %d, want > newBloomFilter(10).m = %d", large.m, small.m)
+	}
+}
+
+// TestBloomFilterZeroAndNegativeN covers newBloomFilter's n<=0 guard, which
+// reloadOnce and buildRocksFilters rely on for an empty coupon file or CF.
+func TestBloomFilterZeroAndNegativeN(t *testing.T) {
+	for _, n := range []int{0, -1} {
+		bf := newBloomFilter(n)
+		if bf.m == 0 || bf.k == 0 {
+			t.Fatalf("newBloomFilter(%d) produced degenerate filter: m=%d k=%d", n, bf.m, bf.k)
+		}
+		if bf.mayContain("ANYTHING1") {
+			t.Fatalf("newBloomFilter(%d) unexpectedly reports a code present before anything was added", n)
+		}
+	}
+}
@@ -3,44 +3,140 @@ package coupons
 import (
 	"bufio"
 	"compress/gzip"
+	"context"
 	"errors"
+	"hash/fnv"
 	"io"
 	"log"
 	"os"
 	"path/filepath"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 // -----------------------------------------------------------------------------
-// Manager: maintains the coupon map + background reload loop
+// Manager: maintains the sharded coupon map + background reload loop
 // -----------------------------------------------------------------------------
 
+// defaultShardCount is used by NewManagerFromFiles/NewEmptyManager; callers
+// that need a different fan-out (e.g. to tune reloadOnce's per-shard builder
+// goroutines) should use NewShardedManagerFromFiles directly.
+const defaultShardCount = 32
+
+// generation is one immutable, fully-built copy of the coupon data: one
+// count map per shard plus the per-file Bloom filters that were built
+// alongside it. Because it's never mutated after reloadOnce builds it,
+// IsValidPromo can read straight out of a generation with no locking at
+// all - the only synchronization is the single atomic.Value swap that
+// publishes a new generation wholesale. That also means filters and shard
+// counts can never be observed as a mix of two reloads: a reader either
+// gets the whole old generation or the whole new one.
+type generation struct {
+	filters     []*bloomFilter
+	shardCounts []map[string]int
+}
+
+func newEmptyGeneration(shardCount int) *generation {
+	counts := make([]map[string]int, shardCount)
+	for i := range counts {
+		counts[i] = make(map[string]int)
+	}
+	return &generation{shardCounts: counts}
+}
+
 type Manager struct {
-	counts         map[string]int
-	mu             sync.RWMutex
+	shardCount int
+	gen        atomic.Value // holds *generation
+
 	files          []string
 	reloadInterval time.Duration
 	quit           chan struct{}
 	wg             sync.WaitGroup
+
+	// filterAvoided counts lookups IsValidPromo rejected via the current
+	// generation's filters alone, without touching a shard map.
+	filterAvoided int64
+
+	// subMu/subs back Subscribe(): every ReloadStats update published during
+	// a reload is fanned out to each subscriber channel.
+	subMu sync.Mutex
+	subs  []chan ReloadStats
+
+	// LookupTimeout, if set, bounds every IsValidPromo call regardless of
+	// the caller's own context deadline. Zero means "use the caller's
+	// context as-is".
+	LookupTimeout time.Duration
+}
+
+// currentGen returns the generation currently published by the most recent
+// reloadOnce, or the empty one NewEmptyManager seeds when there are no
+// coupon files at all.
+func (m *Manager) currentGen() *generation {
+	return m.gen.Load().(*generation)
+}
+
+// ReloadNow triggers a reload and blocks until it completes or ctx is done.
+// The reload itself keeps running in the background even if ctx is
+// cancelled first - reloadOnce has no internal cancellation point - but the
+// caller is freed to respond (e.g. time out an admin request) immediately.
+func (m *Manager) ReloadNow(ctx context.Context) error {
+	done := make(chan error, 1)
+	go func() { done <- m.reloadOnce() }()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-done:
+		return err
+	}
+}
+
+// FilterAvoidedLookups returns the number of IsValidPromo calls that were
+// rejected by the Bloom filters alone, without a shard map lookup.
+func (m *Manager) FilterAvoidedLookups() int64 {
+	return atomic.LoadInt64(&m.filterAvoided)
+}
+
+// shardIndex returns the shard index owning code, keyed on fnv32(code) % shardCount.
+func (m *Manager) shardIndex(code string) int {
+	h := fnv.New32a()
+	h.Write([]byte(code))
+	return int(h.Sum32() % uint32(m.shardCount))
 }
 
 // NewEmptyManager returns a manager with no coupons (used if files missing)
 func NewEmptyManager() *Manager {
-	return &Manager{
-		counts: make(map[string]int),
-		quit:   make(chan struct{}),
+	m := &Manager{
+		shardCount: defaultShardCount,
+		quit:       make(chan struct{}),
 	}
+	m.gen.Store(newEmptyGeneration(defaultShardCount))
+	return m
 }
 
-// NewManagerFromFiles loads initial coupons AND starts background reloader.
+// NewManagerFromFiles loads initial coupons AND starts background reloader,
+// using the default shard count.
 func NewManagerFromFiles(files []string) (*Manager, error) {
+	return NewShardedManagerFromFiles(files, defaultShardCount)
+}
+
+// NewShardedManagerFromFiles loads initial coupons into a map sharded across
+// `shards` buckets and starts the background reloader. Use more shards to
+// reduce how much of the coupon set reloadOnce's fan-out goroutines need to
+// rebuild per bucket; IsValidPromo itself is already lock-free (see
+// generation), so the shard count no longer trades off lookup contention.
+func NewShardedManagerFromFiles(files []string, shards int) (*Manager, error) {
 	if len(files) < 3 {
 		return nil, errors.New("need 3 coupon files")
 	}
+	if shards <= 0 {
+		shards = defaultShardCount
+	}
 
 	m := &Manager{
+		shardCount:     shards,
 		files:          append([]string(nil), files...),
 		reloadInterval: time.Hour, // reload every hour
 		quit:           make(chan struct{}),
@@ -65,25 +161,68 @@ func (m *Manager) Close() {
 }
 
 // IsValidPromo returns true if the code appears in >=2 files.
-func (m *Manager) IsValidPromo(code string) bool {
+//
+// The per-file Bloom filters are checked first: if fewer than 2 of them
+// report the code as possibly present, it cannot be in >=2 files and we
+// return false without ever touching the shard map.
+//
+// Both the filters and the shard map come from a single generation loaded
+// once at the top of this function, so a concurrent reloadOnce swap can
+// never hand this call a mix of one generation's filters and another
+// generation's counts - see the generation doc comment. There's no lock to
+// contend with a reader for either: the shard map is read-only for the
+// lifetime of its generation.
+//
+// ctx is honoured before each remaining unit of work; if it's already done
+// (or LookupTimeout elapses first) IsValidPromo returns ctx.Err() instead of
+// a potentially stale answer. The in-memory path never blocks for long, but
+// this keeps the signature - and behaviour under a cancelled request - the
+// same as the RocksDB-backed Manager.
+func (m *Manager) IsValidPromo(ctx context.Context, code string) (bool, error) {
+	if m.LookupTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, m.LookupTimeout)
+		defer cancel()
+	}
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
 	code = strings.TrimSpace(strings.ToUpper(code))
 	if len(code) < 8 || len(code) > 10 {
-		return false
+		return false, nil
 	}
 
-	m.mu.RLock()
-	defer m.mu.RUnlock()
-	return m.counts[code] >= 2
+	g := m.currentGen()
+
+	if g.filters != nil {
+		present := 0
+		for _, f := range g.filters {
+			if f.mayContain(code) {
+				present++
+			}
+		}
+		if present < 2 {
+			atomic.AddInt64(&m.filterAvoided, 1)
+			return false, nil
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	counts := g.shardCounts[m.shardIndex(code)]
+	return counts[code] >= 2, nil
 }
 
 // Snapshot returns a copy (useful for debugging)
 func (m *Manager) Snapshot() map[string]int {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
-
-	cp := make(map[string]int, len(m.counts))
-	for k, v := range m.counts {
-		cp[k] = v
+	cp := make(map[string]int)
+	for _, counts := range m.currentGen().shardCounts {
+		for k, v := range counts {
+			cp[k] = v
+		}
 	}
 	return cp
 }
@@ -149,7 +288,9 @@ func (m *Manager) reloadOnce() error {
 				set = make(map[string]struct{}, 0)
 			}
 
-			err := fastReadGzipIntoSet(p, set)
+			progress := newReloadProgress(m, p)
+			err := fastReadGzipIntoSet(p, set, progress.update)
+			progress.finish(len(set), err)
 			elapsed := time.Since(start)
 
 			results <- fileRes{idx: idx, path: p, set: set, err: err, elapsed: elapsed}
@@ -179,21 +320,71 @@ func (m *Manager) reloadOnce() error {
 		return anyErr
 	}
 
-	// Aggregate counts
-	newCounts := make(map[string]int, 1024)
-	for _, set := range perFileSets {
+	// Build one Bloom filter per file off to the side - nothing is swapped
+	// in yet.
+	newFilters := make([]*bloomFilter, len(perFileSets))
+	for i, set := range perFileSets {
+		bf := newBloomFilter(len(set))
 		for code := range set {
-			newCounts[code]++
+			bf.add(code)
 		}
+		newFilters[i] = bf
+	}
+
+	// Feed each parsed code into its target shard's builder goroutine. Each
+	// builder computes its shard's full new count map; nothing is published
+	// until the single generation swap below, so a reader can never observe
+	// some shards from this reload and others from the last one.
+	numShards := m.shardCount
+	shardChans := make([]chan string, numShards)
+	for i := range shardChans {
+		shardChans[i] = make(chan string, 1024)
+	}
+
+	newShardCounts := make([]map[string]int, numShards)
+	var buildWG sync.WaitGroup
+	buildWG.Add(numShards)
+	for i := 0; i < numShards; i++ {
+		go func(idx int) {
+			defer buildWG.Done()
+			local := make(map[string]int)
+			for code := range shardChans[idx] {
+				local[code]++
+			}
+			newShardCounts[idx] = local
+		}(i)
 	}
 
-	// Swap atomically
-	m.mu.Lock()
-	m.counts = newCounts
-	m.mu.Unlock()
+	var feedWG sync.WaitGroup
+	for _, set := range perFileSets {
+		feedWG.Add(1)
+		go func(s map[string]struct{}) {
+			defer feedWG.Done()
+			for code := range s {
+				shardChans[m.shardIndex(code)] <- code
+			}
+		}(set)
+	}
+	feedWG.Wait()
+	for _, ch := range shardChans {
+		close(ch)
+	}
+	buildWG.Wait()
+
+	// Publish the new generation with a single atomic.Value store - no lock
+	// is taken at all, so this never contends with IsValidPromo, and there's
+	// no window in which a reader could see some of the old generation and
+	// some of the new one: m.gen.Load() always returns one complete,
+	// never-mutated generation object.
+	m.gen.Store(&generation{filters: newFilters, shardCounts: newShardCounts})
+
+	var total int
+	for _, counts := range newShardCounts {
+		total += len(counts)
+	}
 
-	log.Printf("[couponloader] reload complete: %d total keys @ %s",
-		len(newCounts), time.Now().Format(time.RFC3339))
+	log.Printf("[couponloader] reload complete: %d total keys across %d shards @ %s",
+		total, numShards, time.Now().Format(time.RFC3339))
 
 	return nil
 }
@@ -202,7 +393,23 @@ func (m *Manager) reloadOnce() error {
 // Fast GZIP Reader (faster than Scanner for large files)
 // -----------------------------------------------------------------------------
 
-func fastReadGzipIntoSet(path string, set map[string]struct{}) error {
+// countingReader wraps an io.Reader, tracking cumulative bytes read so
+// fastReadGzipIntoSet can report decompressed-byte progress.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// fastReadGzipIntoSet reads path and parses coupon codes into set. onProgress
+// (may be nil) is called with cumulative decompressed bytes/lines as reading
+// proceeds; the caller decides how often to act on it.
+func fastReadGzipIntoSet(path string, set map[string]struct{}, onProgress func(bytesRead, linesParsed int64)) error {
 	f, err := os.Open(filepath.Clean(path))
 	if err != nil {
 		return err
@@ -215,17 +422,24 @@ func fastReadGzipIntoSet(path string, set map[string]struct{}) error {
 	}
 	defer gz.Close()
 
+	cr := &countingReader{r: gz}
+
 	const bufSize = 1 << 20 // 1MB buffer
-	r := bufio.NewReaderSize(gz, bufSize)
+	r := bufio.NewReaderSize(cr, bufSize)
 
+	var lines int64
 	for {
 		line, err := r.ReadString('\n')
 		if line != "" {
+			lines++
 			line = strings.TrimSpace(strings.ToUpper(line))
 			if len(line) >= 8 && len(line) <= 10 {
 				set[line] = struct{}{}
 			}
 		}
+		if onProgress != nil {
+			onProgress(cr.n, lines)
+		}
 		if err == io.EOF {
 			break
 		}
@@ -0,0 +1,80 @@
+package coupons
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeBenchCouponFile writes n gzip-compressed coupon codes (8-10 chars,
+// shared across all three files so IsValidPromo finds every code in >=2 of
+// them) to dir and returns its path.
+func writeBenchCouponFile(tb testing.TB, dir, name string, n int) string {
+	tb.Helper()
+
+	path := filepath.Join(dir, name)
+	f, err := os.Create(path)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	defer gz.Close()
+
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(gz, "CODE%05d\n", i)
+	}
+	return path
+}
+
+// benchManager builds a Manager with shards shards over the same n codes,
+// replicated across all three files so every code is valid.
+func benchManager(b *testing.B, shards, n int) *Manager {
+	b.Helper()
+
+	dir := b.TempDir()
+	files := []string{
+		writeBenchCouponFile(b, dir, "f1.gz", n),
+		writeBenchCouponFile(b, dir, "f2.gz", n),
+		writeBenchCouponFile(b, dir, "f3.gz", n),
+	}
+
+	mgr, err := NewShardedManagerFromFiles(files, shards)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(mgr.Close)
+	return mgr
+}
+
+// BenchmarkIsValidPromo_Shards compares IsValidPromo throughput under
+// concurrent order traffic at 1, 8 and defaultShardCount shards. Every
+// configuration reads the same lock-free generation, so even the single
+// shard case takes no lock on the lookup path.
+func BenchmarkIsValidPromo_Shards(b *testing.B) {
+	const n = 50_000
+
+	for _, shards := range []int{1, 8, defaultShardCount} {
+		shards := shards
+		b.Run(fmt.Sprintf("shards=%d", shards), func(b *testing.B) {
+			mgr := benchManager(b, shards, n)
+			ctx := context.Background()
+
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				i := 0
+				for pb.Next() {
+					code := fmt.Sprintf("CODE%05d", i%n)
+					if _, err := mgr.IsValidPromo(ctx, code); err != nil {
+						b.Fatal(err)
+					}
+					i++
+				}
+			})
+		})
+	}
+}
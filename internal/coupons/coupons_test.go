@@ -0,0 +1,119 @@
+package coupons
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeCouponFile(t *testing.T, dir, name string, codes ...string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	defer gz.Close()
+
+	for _, c := range codes {
+		fmt.Fprintln(gz, c)
+	}
+	return path
+}
+
+// TestIsValidPromoRequiresTwoFiles checks the core rule (code must appear in
+// >=2 of the 3 coupon files) both for a code present everywhere and one
+// present in only a single file.
+func TestIsValidPromoRequiresTwoFiles(t *testing.T) {
+	dir := t.TempDir()
+	files := []string{
+		writeCouponFile(t, dir, "f1.gz", "TWOFILES1", "ONLYFILE1"),
+		writeCouponFile(t, dir, "f2.gz", "TWOFILES1"),
+		writeCouponFile(t, dir, "f3.gz", "TWOFILES1"),
+	}
+
+	mgr, err := NewShardedManagerFromFiles(files, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mgr.Close()
+
+	ctx := context.Background()
+
+	ok, err := mgr.IsValidPromo(ctx, "TWOFILES1")
+	if err != nil || !ok {
+		t.Fatalf("IsValidPromo(TWOFILES1) = %v, %v; want true, nil", ok, err)
+	}
+
+	ok, err = mgr.IsValidPromo(ctx, "ONLYFILE1")
+	if err != nil || ok {
+		t.Fatalf("IsValidPromo(ONLYFILE1) = %v, %v; want false, nil", ok, err)
+	}
+
+	ok, err = mgr.IsValidPromo(ctx, "NOTACODE")
+	if err != nil || ok {
+		t.Fatalf("IsValidPromo(NOTACODE) = %v, %v; want false, nil", ok, err)
+	}
+}
+
+// TestReloadOnceSwapsGenerationAtomically exercises the generation swap
+// directly: after a reload that changes which codes qualify, every
+// IsValidPromo call must see either entirely the old generation's answer or
+// entirely the new one's - never a filter from one paired with counts from
+// the other. Since reloadOnce publishes with a single atomic.Value.Store,
+// readers racing the swap can only ever observe one generation or the
+// other, so this pins that both pre- and post-reload answers are
+// internally consistent.
+func TestReloadOnceSwapsGenerationAtomically(t *testing.T) {
+	dir := t.TempDir()
+	files := []string{
+		writeCouponFile(t, dir, "f1.gz", "FIRSTGEN1"),
+		writeCouponFile(t, dir, "f2.gz", "FIRSTGEN1"),
+		writeCouponFile(t, dir, "f3.gz", "FIRSTGEN1"),
+	}
+
+	mgr, err := NewShardedManagerFromFiles(files, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mgr.Close()
+
+	ctx := context.Background()
+	if ok, err := mgr.IsValidPromo(ctx, "FIRSTGEN1"); err != nil || !ok {
+		t.Fatalf("before reload: IsValidPromo(FIRSTGEN1) = %v, %v; want true, nil", ok, err)
+	}
+
+	// Rewrite the files with a different qualifying code and reload.
+	writeCouponFile(t, dir, "f1.gz", "SECONDGEN")
+	writeCouponFile(t, dir, "f2.gz", "SECONDGEN")
+	writeCouponFile(t, dir, "f3.gz", "SECONDGEN")
+
+	if err := mgr.ReloadNow(ctx); err != nil {
+		t.Fatalf("ReloadNow: %v", err)
+	}
+
+	if ok, err := mgr.IsValidPromo(ctx, "SECONDGEN"); err != nil || !ok {
+		t.Fatalf("after reload: IsValidPromo(SECONDGEN) = %v, %v; want true, nil", ok, err)
+	}
+	if ok, err := mgr.IsValidPromo(ctx, "FIRSTGEN1"); err != nil || ok {
+		t.Fatalf("after reload: IsValidPromo(FIRSTGEN1) = %v, %v; want false, nil (stale generation dropped)", ok, err)
+	}
+}
+
+// TestNewEmptyManagerRejectsEverything checks the zero-files case never
+// panics (currentGen finds the empty generation NewEmptyManager stores) and
+// correctly rejects every lookup.
+func TestNewEmptyManagerRejectsEverything(t *testing.T) {
+	mgr := NewEmptyManager()
+	ok, err := mgr.IsValidPromo(context.Background(), "ANYCODE12")
+	if err != nil || ok {
+		t.Fatalf("IsValidPromo on empty manager = %v, %v; want false, nil", ok, err)
+	}
+}
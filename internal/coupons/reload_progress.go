@@ -0,0 +1,150 @@
+package coupons
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// progressByteInterval and progressTimeInterval gate how often reload
+// progress is published while reading a coupon file, so a multi-GB file
+// doesn't flood subscribers with an update per line.
+const (
+	progressByteInterval = 64 << 20 // 64MB
+	progressTimeInterval = 500 * time.Millisecond
+)
+
+var (
+	couponReloadBytesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "coupon_reload_bytes_total",
+		Help: "Cumulative bytes read from coupon files during reload, by file.",
+	}, []string{"file"})
+
+	couponReloadDurationSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "coupon_reload_duration_seconds",
+		Help:    "Time to read and parse one coupon file during reload.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"file"})
+
+	couponUniqueCodes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "coupon_unique_codes",
+		Help: "Unique coupon codes found in the last reload of a file.",
+	}, []string{"file"})
+)
+
+func init() {
+	prometheus.MustRegister(couponReloadBytesTotal, couponReloadDurationSeconds, couponUniqueCodes)
+}
+
+// ReloadStats is one progress update for a single coupon file's reload.
+type ReloadStats struct {
+	File        string
+	BytesRead   int64
+	LinesParsed int64
+	UniqueCodes int64
+	Elapsed     time.Duration
+	Err         error
+}
+
+// Subscribe returns a channel of live ReloadStats for every reload this
+// Manager runs after the call (periodic reload and ReloadNow); the initial
+// load in NewShardedManagerFromFiles finishes before a caller can subscribe,
+// so its updates are never delivered. The channel
+// is buffered; a subscriber that falls behind misses intermediate updates
+// rather than blocking the reload. Callers must call Unsubscribe once they
+// stop reading, or the channel (and every future update queued for it)
+// leaks for the lifetime of the Manager.
+func (m *Manager) Subscribe() <-chan ReloadStats {
+	ch := make(chan ReloadStats, 16)
+	m.subMu.Lock()
+	m.subs = append(m.subs, ch)
+	m.subMu.Unlock()
+	return ch
+}
+
+// Unsubscribe removes a channel previously returned by Subscribe from the
+// publish fan-out. It is a no-op if ch was already unsubscribed.
+func (m *Manager) Unsubscribe(ch <-chan ReloadStats) {
+	m.subMu.Lock()
+	defer m.subMu.Unlock()
+	for i, sub := range m.subs {
+		if sub == ch {
+			m.subs = append(m.subs[:i], m.subs[i+1:]...)
+			return
+		}
+	}
+}
+
+func (m *Manager) publish(stats ReloadStats) {
+	m.subMu.Lock()
+	defer m.subMu.Unlock()
+	for _, ch := range m.subs {
+		select {
+		case ch <- stats:
+		default: // slow subscriber: drop, they'll get the next update
+		}
+	}
+}
+
+// reloadProgress tracks one file's in-flight reload so fastReadGzipIntoSet
+// can report byte/line counters without knowing about Manager or metrics.
+type reloadProgress struct {
+	mgr   *Manager
+	file  string
+	start time.Time
+
+	bytesRead     int64
+	linesParsed   int64
+	lastEmitBytes int64
+	lastEmit      time.Time
+}
+
+func newReloadProgress(mgr *Manager, file string) *reloadProgress {
+	now := time.Now()
+	return &reloadProgress{mgr: mgr, file: file, start: now, lastEmit: now}
+}
+
+// update is called frequently (in the worst case, once per line) with
+// cumulative counters. It adds any newly read bytes to
+// coupon_reload_bytes_total on every call, but only publishes a ReloadStats
+// every progressByteInterval bytes or progressTimeInterval, whichever comes
+// first.
+func (p *reloadProgress) update(bytesRead, linesParsed int64) {
+	if delta := bytesRead - p.bytesRead; delta > 0 {
+		couponReloadBytesTotal.WithLabelValues(p.file).Add(float64(delta))
+	}
+	p.bytesRead = bytesRead
+	p.linesParsed = linesParsed
+
+	if bytesRead-p.lastEmitBytes < progressByteInterval && time.Since(p.lastEmit) < progressTimeInterval {
+		return
+	}
+	p.lastEmitBytes = bytesRead
+	p.lastEmit = time.Now()
+	p.mgr.publish(ReloadStats{
+		File:        p.file,
+		BytesRead:   bytesRead,
+		LinesParsed: linesParsed,
+		Elapsed:     time.Since(p.start),
+	})
+}
+
+// finish publishes (and returns) the terminal ReloadStats for this file,
+// recording duration/unique-code metrics regardless of outcome.
+func (p *reloadProgress) finish(uniqueCodes int, err error) ReloadStats {
+	elapsed := time.Since(p.start)
+	couponReloadDurationSeconds.WithLabelValues(p.file).Observe(elapsed.Seconds())
+	if err == nil {
+		couponUniqueCodes.WithLabelValues(p.file).Set(float64(uniqueCodes))
+	}
+	stats := ReloadStats{
+		File:        p.file,
+		BytesRead:   p.bytesRead,
+		LinesParsed: p.linesParsed,
+		UniqueCodes: int64(uniqueCodes),
+		Elapsed:     elapsed,
+		Err:         err,
+	}
+	p.mgr.publish(stats)
+	return stats
+}
@@ -1,9 +1,12 @@
 package coupons
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/linxGnu/grocksdb"
 )
@@ -14,6 +17,19 @@ type Manager struct {
 	cf   map[string]*grocksdb.ColumnFamilyHandle // keys: "file1","file2","file3"
 	mu   sync.RWMutex
 	// no in-memory counts are stored by default; Snapshot builds them on demand
+
+	// filters holds one Bloom filter per CF (same keys as cf), built once at
+	// open time by scanning each CF. IsValidPromo checks these first so a
+	// code absent from >=2 CFs is rejected without ever issuing a GetCF -
+	// the whole point of a fast-reject filter in front of a disk-backed
+	// store. filterAvoided counts lookups rejected this way.
+	filters       map[string]*bloomFilter
+	filterAvoided int64
+
+	// LookupTimeout, if set, bounds every IsValidPromo call regardless of
+	// the caller's own context deadline. Zero means "use the caller's
+	// context as-is".
+	LookupTimeout time.Duration
 }
 
 // NewManagerFromRocks opens an existing RocksDB at dbPath with column families:
@@ -52,9 +68,51 @@ func NewManagerFromRocks(dbPath string) (*Manager, error) {
 	m.cf["file2"] = handles[2]
 	m.cf["file3"] = handles[3]
 
+	m.filters = buildRocksFilters(db, m.cf)
+
 	return m, nil
 }
 
+// buildRocksFilters scans every CF twice (once to count its keys for sizing,
+// once to add them) and returns a Bloom filter per CF keyed the same way as
+// cf, so IsValidPromo can reject a code up front without a GetCF round-trip
+// per CF.
+func buildRocksFilters(db *grocksdb.DB, cf map[string]*grocksdb.ColumnFamilyHandle) map[string]*bloomFilter {
+	ro := grocksdb.NewDefaultReadOptions()
+	defer ro.Destroy()
+
+	filters := make(map[string]*bloomFilter, len(cf))
+	for name, handle := range cf {
+		if handle == nil {
+			continue
+		}
+		it := db.NewIteratorCF(ro, handle)
+		var n int
+		for it.SeekToFirst(); it.Valid(); it.Next() {
+			n++
+		}
+		it.Close()
+
+		bf := newBloomFilter(n)
+		it = db.NewIteratorCF(ro, handle)
+		for it.SeekToFirst(); it.Valid(); it.Next() {
+			k := it.Key()
+			bf.add(string(k.Data()))
+			k.Free()
+		}
+		it.Close()
+
+		filters[name] = bf
+	}
+	return filters
+}
+
+// FilterAvoidedLookups returns the number of IsValidPromo calls rejected by
+// the Bloom filters alone, without a GetCF call.
+func (m *Manager) FilterAvoidedLookups() int64 {
+	return atomic.LoadInt64(&m.filterAvoided)
+}
+
 // Close closes the DB and destroys CF handles.
 func (m *Manager) Close() {
 	if m.db == nil {
@@ -70,34 +128,104 @@ func (m *Manager) Close() {
 	m.db = nil
 }
 
-// IsValidPromo returns true if the code exists in at least 2 column families.
-func (m *Manager) IsValidPromo(code string) bool {
+// IsValidPromo returns true if the code exists in at least 2 column
+// families. The per-CF Bloom filters are checked first: if fewer than 2
+// report the code as possibly present, it cannot be in >=2 CFs and we
+// return without a single GetCF call - the expensive disk round-trip the
+// filters exist to avoid. Otherwise the three CFs are queried concurrently;
+// as soon as the "seen in >=2 CFs" invariant is satisfied we return without
+// waiting on the slowest lookup. ctx is honoured while waiting on the
+// slower CFs - a stalled disk must not hold PlaceOrder past the client's
+// deadline - and is checked up front so an already-cancelled request never
+// issues a GetCF at all.
+func (m *Manager) IsValidPromo(ctx context.Context, code string) (bool, error) {
+	if m.LookupTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, m.LookupTimeout)
+		defer cancel()
+	}
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
 	code = strings.TrimSpace(strings.ToUpper(code))
 	if len(code) < 8 || len(code) > 10 {
-		return false
+		return false, nil
+	}
+
+	names := [3]string{"file1", "file2", "file3"}
+
+	if m.filters != nil {
+		present := 0
+		for _, name := range names {
+			if f := m.filters[name]; f != nil && f.mayContain(code) {
+				present++
+			}
+		}
+		if present < 2 {
+			atomic.AddInt64(&m.filterAvoided, 1)
+			return false, nil
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return false, err
 	}
+
 	ro := grocksdb.NewDefaultReadOptions()
-	defer ro.Destroy()
 
-	cnt := 0
-	for _, name := range []string{"file1", "file2", "file3"} {
+	found := make(chan bool, len(names))
+	var wg sync.WaitGroup
+	for _, name := range names {
 		c := m.cf[name]
 		if c == nil {
+			found <- false
 			continue
 		}
-		val, err := m.db.GetCF(ro, c, []byte(code))
-		if err == nil && val != nil && val.Size() > 0 {
-			cnt++
-			val.Free()
-		} else if val != nil {
-			// free even on error
-			val.Free()
-		}
-		if cnt >= 2 {
-			return true
+		wg.Add(1)
+		go func(cf *grocksdb.ColumnFamilyHandle) {
+			defer wg.Done()
+			val, err := m.db.GetCF(ro, cf, []byte(code))
+			ok := err == nil && val != nil && val.Size() > 0
+			if val != nil {
+				val.Free()
+			}
+			// Never block here: the reader below may already have returned
+			// on ctx.Done() and stopped draining this channel.
+			select {
+			case found <- ok:
+			default:
+			}
+		}(c)
+	}
+	// ro is only destroyed once every lookup goroutine is done with it.
+	go func() {
+		wg.Wait()
+		close(found)
+		ro.Destroy()
+	}()
+
+	cnt, received := 0, 0
+	for {
+		select {
+		case ok, open := <-found:
+			if !open {
+				return cnt >= 2, nil
+			}
+			received++
+			if ok {
+				cnt++
+			}
+			if cnt >= 2 {
+				return true, nil
+			}
+			if received >= len(names) {
+				return false, nil
+			}
+		case <-ctx.Done():
+			return false, ctx.Err()
 		}
 	}
-	return false
 }
 
 // Snapshot returns a map[string]int with counts per code (same format as your old manager).
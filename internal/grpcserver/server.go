@@ -0,0 +1,145 @@
+// Package grpcserver exposes the same cart operations as the Gin HTTP API
+// (ListProducts, GetProduct, PlaceOrder) over gRPC, sharing the same
+// transport-agnostic store and coupons packages as the REST server.
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/manis005/kart-challenge/internal/coupons"
+	"github.com/manis005/kart-challenge/internal/kartpb"
+	"github.com/manis005/kart-challenge/internal/store"
+)
+
+// apiKeyMetadataKey is the gRPC metadata key carrying the same credential as
+// the REST API's "api_key" header.
+const apiKeyMetadataKey = "api_key"
+
+// Server implements kartpb.ProductServiceServer and kartpb.OrderServiceServer
+// on top of the shared store.Store and coupon manager.
+type Server struct {
+	kartpb.UnimplementedProductServiceServer
+	kartpb.UnimplementedOrderServiceServer
+
+	Store   store.Store
+	Coupons *coupons.Manager
+	APIKey  string
+}
+
+// NewServer constructs the gRPC service implementation.
+func NewServer(st store.Store, mgr *coupons.Manager, apiKey string) *Server {
+	return &Server{
+		Store:   st,
+		Coupons: mgr,
+		APIKey:  apiKey,
+	}
+}
+
+func toPbProduct(p store.Product) *kartpb.Product {
+	return &kartpb.Product{
+		Id:       p.ID,
+		Name:     p.Name,
+		Price:    float32(p.Price),
+		Category: p.Category,
+	}
+}
+
+// ListProducts implements kartpb.ProductServiceServer.
+func (s *Server) ListProducts(ctx context.Context, _ *kartpb.ListProductsRequest) (*kartpb.ListProductsResponse, error) {
+	prods, err := s.Store.ListProducts()
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list products: "+err.Error())
+	}
+	out := make([]*kartpb.Product, 0, len(prods))
+	for _, p := range prods {
+		out = append(out, toPbProduct(p))
+	}
+	return &kartpb.ListProductsResponse{Products: out}, nil
+}
+
+// GetProduct implements kartpb.ProductServiceServer.
+func (s *Server) GetProduct(ctx context.Context, req *kartpb.GetProductRequest) (*kartpb.Product, error) {
+	idStr := strconv.FormatInt(req.ProductId, 10)
+	p, ok, err := s.Store.GetProductByID(idStr)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get product: "+err.Error())
+	}
+	if !ok {
+		return nil, status.Error(codes.NotFound, "product not found")
+	}
+	return toPbProduct(p), nil
+}
+
+func apiKeyFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	vals := md.Get(apiKeyMetadataKey)
+	if len(vals) == 0 {
+		return ""
+	}
+	return vals[0]
+}
+
+// PlaceOrder implements kartpb.OrderServiceServer, mapping the api_key
+// metadata and translating store/coupon errors into gRPC status codes the
+// same way the REST handler maps them onto ApiResponse/HTTP statuses.
+func (s *Server) PlaceOrder(ctx context.Context, req *kartpb.PlaceOrderRequest) (*kartpb.Order, error) {
+	if ak := apiKeyFromContext(ctx); ak == "" || ak != s.APIKey {
+		return nil, status.Error(codes.Unauthenticated, "invalid or missing api_key")
+	}
+
+	if len(req.Items) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "items is required and must be non-empty")
+	}
+
+	items := make([]store.OrderItem, 0, len(req.Items))
+	for _, it := range req.Items {
+		pid := strings.TrimSpace(it.ProductId)
+		if pid == "" || it.Quantity <= 0 {
+			return nil, status.Error(codes.InvalidArgument, "each item must have productId and quantity > 0")
+		}
+		items = append(items, store.OrderItem{ProductID: pid, Quantity: int(it.Quantity)})
+	}
+
+	if code := strings.TrimSpace(req.CouponCode); code != "" {
+		valid, err := s.Coupons.IsValidPromo(ctx, code)
+		if err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
+				return nil, status.Error(codes.DeadlineExceeded, "coupon lookup timed out")
+			}
+			return nil, status.Error(codes.Internal, "coupon lookup failed: "+err.Error())
+		}
+		if !valid {
+			return nil, status.Error(codes.FailedPrecondition, "invalid coupon code")
+		}
+	}
+
+	order, err := s.Store.CreateOrder(items)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, "failed to create order: "+err.Error())
+	}
+
+	pbItems := make([]*kartpb.OrderItem, 0, len(order.Items))
+	for _, it := range order.Items {
+		pbItems = append(pbItems, &kartpb.OrderItem{ProductId: it.ProductID, Quantity: int32(it.Quantity)})
+	}
+	pbProducts := make([]*kartpb.Product, 0, len(order.Products))
+	for _, p := range order.Products {
+		pbProducts = append(pbProducts, toPbProduct(p))
+	}
+
+	return &kartpb.Order{
+		Id:       order.ID,
+		Items:    pbItems,
+		Products: pbProducts,
+	}, nil
+}
@@ -0,0 +1,325 @@
+// Package postgres implements store.Store on top of Postgres via
+// database/sql + pgx, for deployments that need orders to survive a
+// restart (the in-memory store remains the default for local dev/tests).
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"fmt"
+	"time"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+
+	"github.com/manis005/kart-challenge/internal/store"
+)
+
+func nowUTC() time.Time {
+	return time.Now().UTC()
+}
+
+//go:embed migrations.sql
+var migrations string
+
+// PostgresStore implements store.Store against a Postgres database.
+type PostgresStore struct {
+	db *sql.DB
+}
+
+var _ store.Store = (*PostgresStore)(nil)
+
+// Open connects to dsn, runs migrations, and returns a ready PostgresStore.
+func Open(ctx context.Context, dsn string) (*PostgresStore, error) {
+	db, err := sql.Open("pgx", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("open postgres: %w", err)
+	}
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("ping postgres: %w", err)
+	}
+
+	s := &PostgresStore{db: db}
+	if err := s.migrate(ctx); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *PostgresStore) migrate(ctx context.Context) error {
+	if _, err := s.db.ExecContext(ctx, migrations); err != nil {
+		return fmt.Errorf("run migrations: %w", err)
+	}
+	return nil
+}
+
+// Close closes the underlying database connection pool.
+func (s *PostgresStore) Close() error {
+	return s.db.Close()
+}
+
+func (s *PostgresStore) AddProduct(p store.Product) error {
+	_, err := s.db.Exec(
+		`INSERT INTO products (id, name, price, category) VALUES ($1, $2, $3, $4)
+		 ON CONFLICT (id) DO UPDATE SET name = $2, price = $3, category = $4`,
+		p.ID, p.Name, p.Price, p.Category,
+	)
+	if err != nil {
+		return fmt.Errorf("add product: %w", err)
+	}
+	return nil
+}
+
+func (s *PostgresStore) ListProducts() ([]store.Product, error) {
+	rows, err := s.db.Query(`SELECT id, name, price, category FROM products`)
+	if err != nil {
+		return nil, fmt.Errorf("list products: %w", err)
+	}
+	defer rows.Close()
+
+	var out []store.Product
+	for rows.Next() {
+		var p store.Product
+		if err := rows.Scan(&p.ID, &p.Name, &p.Price, &p.Category); err != nil {
+			return nil, fmt.Errorf("scan product: %w", err)
+		}
+		out = append(out, p)
+	}
+	return out, rows.Err()
+}
+
+func (s *PostgresStore) GetProductByID(id string) (store.Product, bool, error) {
+	var p store.Product
+	err := s.db.QueryRow(`SELECT id, name, price, category FROM products WHERE id = $1`, id).
+		Scan(&p.ID, &p.Name, &p.Price, &p.Category)
+	if err == sql.ErrNoRows {
+		return store.Product{}, false, nil
+	}
+	if err != nil {
+		return store.Product{}, false, fmt.Errorf("get product: %w", err)
+	}
+	return p, true, nil
+}
+
+// CreateOrder runs entirely inside one transaction: it verifies every
+// product exists, inserts the order + items, and returns the assembled
+// order - closing the "product not found" race the in-memory store has
+// under concurrent order placement, since the product existence check and
+// the insert are isolated together.
+func (s *PostgresStore) CreateOrder(items []store.OrderItem) (store.Order, error) {
+	if len(items) == 0 {
+		return store.Order{}, fmt.Errorf("no items")
+	}
+
+	ctx := context.Background()
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return store.Order{}, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	products := make([]store.Product, 0, len(items))
+	for _, it := range items {
+		var p store.Product
+		err := tx.QueryRowContext(ctx,
+			`SELECT id, name, price, category FROM products WHERE id = $1 FOR SHARE`, it.ProductID,
+		).Scan(&p.ID, &p.Name, &p.Price, &p.Category)
+		if err == sql.ErrNoRows {
+			return store.Order{}, fmt.Errorf("product not found: %s", it.ProductID)
+		}
+		if err != nil {
+			return store.Order{}, fmt.Errorf("lookup product %s: %w", it.ProductID, err)
+		}
+		products = append(products, p)
+	}
+
+	var orderID string
+	now := nowUTC()
+	err = tx.QueryRowContext(ctx,
+		`INSERT INTO orders (id, status, created, updated_at)
+		 VALUES (nextval('orders_id_seq')::text, $1, $2, $2) RETURNING id`,
+		store.OrderCreated, now,
+	).Scan(&orderID)
+	if err != nil {
+		return store.Order{}, fmt.Errorf("insert order: %w", err)
+	}
+
+	for _, it := range items {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO order_items (order_id, product_id, quantity) VALUES ($1, $2, $3)`,
+			orderID, it.ProductID, it.Quantity,
+		); err != nil {
+			return store.Order{}, fmt.Errorf("insert order item: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return store.Order{}, fmt.Errorf("commit order: %w", err)
+	}
+
+	return store.Order{
+		ID:        orderID,
+		Items:     items,
+		Products:  products,
+		Created:   now,
+		Status:    store.OrderCreated,
+		UpdatedAt: now,
+	}, nil
+}
+
+func (s *PostgresStore) GetOrderByID(id string) (store.Order, bool, error) {
+	var o store.Order
+	o.ID = id
+	err := s.db.QueryRow(
+		`SELECT status, created, updated_at FROM orders WHERE id = $1`, id,
+	).Scan(&o.Status, &o.Created, &o.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return store.Order{}, false, nil
+	}
+	if err != nil {
+		return store.Order{}, false, fmt.Errorf("get order: %w", err)
+	}
+
+	itemRows, err := s.db.Query(
+		`SELECT oi.product_id, oi.quantity, p.id, p.name, p.price, p.category
+		 FROM order_items oi JOIN products p ON p.id = oi.product_id
+		 WHERE oi.order_id = $1`, id,
+	)
+	if err != nil {
+		return store.Order{}, false, fmt.Errorf("get order items: %w", err)
+	}
+	defer itemRows.Close()
+
+	for itemRows.Next() {
+		var it store.OrderItem
+		var p store.Product
+		if err := itemRows.Scan(&it.ProductID, &it.Quantity, &p.ID, &p.Name, &p.Price, &p.Category); err != nil {
+			return store.Order{}, false, fmt.Errorf("scan order item: %w", err)
+		}
+		o.Items = append(o.Items, it)
+		o.Products = append(o.Products, p)
+	}
+	if err := itemRows.Err(); err != nil {
+		return store.Order{}, false, err
+	}
+
+	eventRows, err := s.db.Query(
+		`SELECT from_status, to_status, at, reason FROM order_status_events WHERE order_id = $1 ORDER BY at`, id,
+	)
+	if err != nil {
+		return store.Order{}, false, fmt.Errorf("get order history: %w", err)
+	}
+	defer eventRows.Close()
+
+	for eventRows.Next() {
+		var ev store.StatusEvent
+		if err := eventRows.Scan(&ev.From, &ev.To, &ev.At, &ev.Reason); err != nil {
+			return store.Order{}, false, fmt.Errorf("scan status event: %w", err)
+		}
+		o.History = append(o.History, ev)
+	}
+
+	return o, true, eventRows.Err()
+}
+
+func (s *PostgresStore) UpdateOrderStatus(id string, from, to store.OrderStatus) error {
+	// Check existence first, the same way CancelOrder does, so an unknown
+	// id reports ErrOrderNotFound instead of being folded into
+	// ErrIllegalTransition by requireOneRowUpdated below.
+	if _, ok, err := s.GetOrderByID(id); err != nil {
+		return err
+	} else if !ok {
+		return store.ErrOrderNotFound
+	}
+
+	if !store.IsLegalTransition(from, to) {
+		return fmt.Errorf("%w: %s -> %s", store.ErrIllegalTransition, from, to)
+	}
+
+	ctx := context.Background()
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx,
+		`UPDATE orders SET status = $1, updated_at = $2 WHERE id = $3 AND status = $4`,
+		to, nowUTC(), id, from,
+	)
+	if err != nil {
+		return fmt.Errorf("update order status: %w", err)
+	}
+	if err := requireOneRowUpdated(res, id); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO order_status_events (order_id, from_status, to_status, at) VALUES ($1, $2, $3, $4)`,
+		id, from, to, nowUTC(),
+	); err != nil {
+		return fmt.Errorf("insert status event: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+func (s *PostgresStore) CancelOrder(id, reason string) error {
+	order, ok, err := s.GetOrderByID(id)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return store.ErrOrderNotFound
+	}
+	if !store.IsLegalTransition(order.Status, store.OrderCancelled) {
+		return fmt.Errorf("%w: cannot cancel order in status %s", store.ErrIllegalTransition, order.Status)
+	}
+
+	ctx := context.Background()
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx,
+		`UPDATE orders SET status = $1, updated_at = $2 WHERE id = $3 AND status = $4`,
+		store.OrderCancelled, nowUTC(), id, order.Status,
+	)
+	if err != nil {
+		return fmt.Errorf("cancel order: %w", err)
+	}
+	if err := requireOneRowUpdated(res, id); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO order_status_events (order_id, from_status, to_status, at, reason) VALUES ($1, $2, $3, $4, $5)`,
+		id, order.Status, store.OrderCancelled, nowUTC(), reason,
+	); err != nil {
+		return fmt.Errorf("insert status event: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// requireOneRowUpdated translates a 0-row UPDATE into ErrIllegalTransition.
+// Callers must have already confirmed the order exists (as UpdateOrderStatus
+// and CancelOrder both do via GetOrderByID before reaching here), so a 0-row
+// result here means only one thing: the row's status no longer equals the
+// expected `from`/order.Status. For CancelOrder that can only happen between
+// the read and the UPDATE; UpdateOrderStatus's read checks existence only, so
+// a caller-supplied `from` that was already stale lands here too.
+func requireOneRowUpdated(res sql.Result, id string) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("rows affected: %w", err)
+	}
+	if n == 0 {
+		return fmt.Errorf("%w: order %s status changed concurrently", store.ErrIllegalTransition, id)
+	}
+	return nil
+}
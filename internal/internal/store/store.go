@@ -19,11 +19,72 @@ type OrderItem struct {
 	Quantity  int    `json:"quantity"`
 }
 
+// OrderStatus is the lifecycle state of an Order.
+type OrderStatus string
+
+const (
+	OrderCreated   OrderStatus = "created"
+	OrderConfirmed OrderStatus = "confirmed"
+	OrderFulfilled OrderStatus = "fulfilled"
+	OrderCancelled OrderStatus = "cancelled"
+	OrderRefunded  OrderStatus = "refunded"
+)
+
+// legalTransitions enumerates the only allowed OrderStatus jumps:
+// Created -> Confirmed -> Fulfilled -> Refunded, plus Cancelled from Created
+// or Confirmed. A fulfilled order cannot be cancelled, and Cancelled and
+// Refunded are terminal.
+var legalTransitions = map[OrderStatus][]OrderStatus{
+	OrderCreated:   {OrderConfirmed, OrderCancelled},
+	OrderConfirmed: {OrderFulfilled, OrderCancelled},
+	OrderFulfilled: {OrderRefunded},
+}
+
+func isLegalTransition(from, to OrderStatus) bool {
+	for _, allowed := range legalTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// IsLegalTransition reports whether from->to is an allowed OrderStatus
+// transition. Exported so other Store implementations (e.g. store/postgres)
+// can enforce the same state machine as InMemoryStore.
+func IsLegalTransition(from, to OrderStatus) bool {
+	return isLegalTransition(from, to)
+}
+
+// StatusEvent records one transition in an order's history.
+type StatusEvent struct {
+	From   OrderStatus `json:"from"`
+	To     OrderStatus `json:"to"`
+	At     time.Time   `json:"at"`
+	Reason string      `json:"reason,omitempty"`
+}
+
 type Order struct {
-	ID       string      `json:"id"`
-	Items    []OrderItem `json:"items"`
-	Products []Product   `json:"products"`
-	Created  time.Time   `json:"created"`
+	ID        string        `json:"id"`
+	Items     []OrderItem   `json:"items"`
+	Products  []Product     `json:"products"`
+	Created   time.Time     `json:"created"`
+	Status    OrderStatus   `json:"status"`
+	UpdatedAt time.Time     `json:"updatedAt"`
+	History   []StatusEvent `json:"history"`
+}
+
+// Store is the storage interface ServerImpl depends on, so the backend can
+// be swapped (in-memory for tests/local dev, Postgres for production)
+// without touching the server or gRPC layers.
+type Store interface {
+	AddProduct(p Product) error
+	ListProducts() ([]Product, error)
+	GetProductByID(id string) (Product, bool, error)
+	CreateOrder(items []OrderItem) (Order, error)
+	GetOrderByID(id string) (Order, bool, error)
+	UpdateOrderStatus(id string, from, to OrderStatus) error
+	CancelOrder(id, reason string) error
 }
 
 type InMemoryStore struct {
@@ -41,27 +102,28 @@ func NewInMemoryStore() *InMemoryStore {
 	}
 }
 
-func (s *InMemoryStore) AddProduct(p Product) {
+func (s *InMemoryStore) AddProduct(p Product) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.products[p.ID] = p
+	return nil
 }
 
-func (s *InMemoryStore) ListProducts() []Product {
+func (s *InMemoryStore) ListProducts() ([]Product, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 	out := make([]Product, 0, len(s.products))
 	for _, p := range s.products {
 		out = append(out, p)
 	}
-	return out
+	return out, nil
 }
 
-func (s *InMemoryStore) GetProductByID(id string) (Product, bool) {
+func (s *InMemoryStore) GetProductByID(id string) (Product, bool, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 	p, ok := s.products[id]
-	return p, ok
+	return p, ok, nil
 }
 
 func (s *InMemoryStore) CreateOrder(items []OrderItem) (Order, error) {
@@ -83,12 +145,84 @@ func (s *InMemoryStore) CreateOrder(items []OrderItem) (Order, error) {
 		products = append(products, p)
 	}
 
+	now := time.Now().UTC()
 	order := Order{
-		ID:       orderID,
-		Items:    items,
-		Products: products,
-		Created:  time.Now().UTC(),
+		ID:        orderID,
+		Items:     items,
+		Products:  products,
+		Created:   now,
+		Status:    OrderCreated,
+		UpdatedAt: now,
 	}
 	s.orders[orderID] = order
 	return order, nil
 }
+
+// GetOrderByID returns the order with the given id.
+func (s *InMemoryStore) GetOrderByID(id string) (Order, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	o, ok := s.orders[id]
+	return o, ok, nil
+}
+
+// compile-time assertion that InMemoryStore satisfies Store.
+var _ Store = (*InMemoryStore)(nil)
+
+// ErrOrderNotFound is returned by order lifecycle methods when id is unknown.
+var ErrOrderNotFound = errors.New("order not found")
+
+// ErrIllegalTransition is returned when a status transition is not allowed
+// by the order lifecycle state machine.
+var ErrIllegalTransition = errors.New("illegal order status transition")
+
+// UpdateOrderStatus atomically moves order id from status `from` to `to`,
+// appending a StatusEvent to its history. It rejects the call if the order's
+// current status no longer matches `from` (optimistic concurrency) or if
+// from->to is not a legal transition (e.g. cancelling a fulfilled order).
+func (s *InMemoryStore) UpdateOrderStatus(id string, from, to OrderStatus) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	order, ok := s.orders[id]
+	if !ok {
+		return ErrOrderNotFound
+	}
+	if order.Status != from {
+		return fmt.Errorf("%w: order %s is %s, not %s", ErrIllegalTransition, id, order.Status, from)
+	}
+	if !isLegalTransition(from, to) {
+		return fmt.Errorf("%w: %s -> %s", ErrIllegalTransition, from, to)
+	}
+
+	now := time.Now().UTC()
+	order.History = append(order.History, StatusEvent{From: from, To: to, At: now})
+	order.Status = to
+	order.UpdatedAt = now
+	s.orders[id] = order
+	return nil
+}
+
+// CancelOrder transitions order id to OrderCancelled from its current status
+// (Created or Confirmed), recording reason on the resulting StatusEvent. It
+// fails with ErrIllegalTransition if the order is already fulfilled,
+// refunded or cancelled.
+func (s *InMemoryStore) CancelOrder(id, reason string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	order, ok := s.orders[id]
+	if !ok {
+		return ErrOrderNotFound
+	}
+	if !isLegalTransition(order.Status, OrderCancelled) {
+		return fmt.Errorf("%w: cannot cancel order in status %s", ErrIllegalTransition, order.Status)
+	}
+
+	now := time.Now().UTC()
+	order.History = append(order.History, StatusEvent{From: order.Status, To: OrderCancelled, At: now, Reason: reason})
+	order.Status = OrderCancelled
+	order.UpdatedAt = now
+	s.orders[id] = order
+	return nil
+}
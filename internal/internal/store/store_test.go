@@ -0,0 +1,187 @@
+package store
+
+import (
+	"errors"
+	"testing"
+)
+
+func newStoreWithOrder(t *testing.T) (*InMemoryStore, string) {
+	t.Helper()
+
+	s := NewInMemoryStore()
+	if err := s.AddProduct(Product{ID: "p1", Name: "Widget", Price: 1, Category: "tools"}); err != nil {
+		t.Fatal(err)
+	}
+	order, err := s.CreateOrder([]OrderItem{{ProductID: "p1", Quantity: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, order.ID
+}
+
+func TestIsLegalTransition(t *testing.T) {
+	tests := []struct {
+		from, to OrderStatus
+		want     bool
+	}{
+		{OrderCreated, OrderConfirmed, true},
+		{OrderCreated, OrderCancelled, true},
+		{OrderCreated, OrderFulfilled, false},
+		{OrderCreated, OrderRefunded, false},
+		{OrderConfirmed, OrderFulfilled, true},
+		{OrderConfirmed, OrderCancelled, true},
+		{OrderConfirmed, OrderRefunded, false},
+		{OrderFulfilled, OrderRefunded, true},
+		{OrderFulfilled, OrderCancelled, false},
+		{OrderFulfilled, OrderConfirmed, false},
+		{OrderCancelled, OrderConfirmed, false},
+		{OrderRefunded, OrderCancelled, false},
+	}
+
+	for _, tc := range tests {
+		if got := IsLegalTransition(tc.from, tc.to); got != tc.want {
+			t.Errorf("IsLegalTransition(%s, %s) = %v, want %v", tc.from, tc.to, got, tc.want)
+		}
+	}
+}
+
+func TestUpdateOrderStatusUnknownID(t *testing.T) {
+	s := NewInMemoryStore()
+	err := s.UpdateOrderStatus("does-not-exist", OrderCreated, OrderConfirmed)
+	if !errors.Is(err, ErrOrderNotFound) {
+		t.Fatalf("UpdateOrderStatus on unknown id = %v, want ErrOrderNotFound", err)
+	}
+}
+
+func TestUpdateOrderStatusIllegalJump(t *testing.T) {
+	s, id := newStoreWithOrder(t)
+
+	// Created -> Fulfilled skips Confirmed and is not a legal jump.
+	err := s.UpdateOrderStatus(id, OrderCreated, OrderFulfilled)
+	if !errors.Is(err, ErrIllegalTransition) {
+		t.Fatalf("UpdateOrderStatus(Created->Fulfilled) = %v, want ErrIllegalTransition", err)
+	}
+
+	order, ok, err := s.GetOrderByID(id)
+	if err != nil || !ok {
+		t.Fatalf("GetOrderByID: %v, %v, %v", order, ok, err)
+	}
+	if order.Status != OrderCreated {
+		t.Fatalf("order status = %s, want unchanged %s after rejected transition", order.Status, OrderCreated)
+	}
+	if len(order.History) != 0 {
+		t.Fatalf("order history = %v, want empty after rejected transition", order.History)
+	}
+}
+
+func TestUpdateOrderStatusStaleFromMismatch(t *testing.T) {
+	s, id := newStoreWithOrder(t)
+
+	if err := s.UpdateOrderStatus(id, OrderCreated, OrderConfirmed); err != nil {
+		t.Fatalf("UpdateOrderStatus(Created->Confirmed): %v", err)
+	}
+
+	// Caller still thinks the order is Created (stale read); the store has
+	// already moved it to Confirmed, so this must be rejected as an
+	// optimistic-concurrency conflict rather than silently applied.
+	err := s.UpdateOrderStatus(id, OrderCreated, OrderCancelled)
+	if !errors.Is(err, ErrIllegalTransition) {
+		t.Fatalf("UpdateOrderStatus with stale from = %v, want ErrIllegalTransition", err)
+	}
+
+	order, _, err := s.GetOrderByID(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.Status != OrderConfirmed {
+		t.Fatalf("order status = %s, want %s (stale update must not apply)", order.Status, OrderConfirmed)
+	}
+}
+
+func TestCancelOrderAfterFulfilled(t *testing.T) {
+	s, id := newStoreWithOrder(t)
+
+	if err := s.UpdateOrderStatus(id, OrderCreated, OrderConfirmed); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.UpdateOrderStatus(id, OrderConfirmed, OrderFulfilled); err != nil {
+		t.Fatal(err)
+	}
+
+	err := s.CancelOrder(id, "changed my mind")
+	if !errors.Is(err, ErrIllegalTransition) {
+		t.Fatalf("CancelOrder on fulfilled order = %v, want ErrIllegalTransition", err)
+	}
+
+	order, _, err := s.GetOrderByID(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.Status != OrderFulfilled {
+		t.Fatalf("order status = %s, want unchanged %s", order.Status, OrderFulfilled)
+	}
+}
+
+func TestCancelOrderUnknownID(t *testing.T) {
+	s := NewInMemoryStore()
+	err := s.CancelOrder("does-not-exist", "reason")
+	if !errors.Is(err, ErrOrderNotFound) {
+		t.Fatalf("CancelOrder on unknown id = %v, want ErrOrderNotFound", err)
+	}
+}
+
+func TestOrderHistoryOrdering(t *testing.T) {
+	s, id := newStoreWithOrder(t)
+
+	if err := s.UpdateOrderStatus(id, OrderCreated, OrderConfirmed); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.UpdateOrderStatus(id, OrderConfirmed, OrderFulfilled); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.UpdateOrderStatus(id, OrderFulfilled, OrderRefunded); err != nil {
+		t.Fatal(err)
+	}
+
+	order, _, err := s.GetOrderByID(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantHistory := []StatusEvent{
+		{From: OrderCreated, To: OrderConfirmed},
+		{From: OrderConfirmed, To: OrderFulfilled},
+		{From: OrderFulfilled, To: OrderRefunded},
+	}
+	if len(order.History) != len(wantHistory) {
+		t.Fatalf("history length = %d, want %d (history: %+v)", len(order.History), len(wantHistory), order.History)
+	}
+	for i, want := range wantHistory {
+		got := order.History[i]
+		if got.From != want.From || got.To != want.To {
+			t.Errorf("history[%d] = %s->%s, want %s->%s", i, got.From, got.To, want.From, want.To)
+		}
+	}
+	if order.Status != OrderRefunded {
+		t.Fatalf("final order status = %s, want %s", order.Status, OrderRefunded)
+	}
+}
+
+func TestCancelOrderRecordsReason(t *testing.T) {
+	s, id := newStoreWithOrder(t)
+
+	if err := s.CancelOrder(id, "out of stock"); err != nil {
+		t.Fatal(err)
+	}
+
+	order, _, err := s.GetOrderByID(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.Status != OrderCancelled {
+		t.Fatalf("order status = %s, want %s", order.Status, OrderCancelled)
+	}
+	if len(order.History) != 1 || order.History[0].Reason != "out of stock" {
+		t.Fatalf("history = %+v, want one event with reason %q", order.History, "out of stock")
+	}
+}
@@ -0,0 +1,99 @@
+// Package kartpb holds the Go types for proto/kart.proto.
+//
+// This file is hand-written, not protoc-gen-go output: protoc and the Go
+// toolchain aren't available in the environment these messages were added
+// in, and there's no go.mod pinning a google.golang.org/protobuf version
+// for protoc-gen-go to target anyway. Once both are available, run `make
+// generate-proto` (see the Makefile) to replace this file and
+// kart_grpc.pb.go with real generated output - the field numbers and
+// message/service shapes are kept hand-in-sync with proto/kart.proto here.
+//
+// Each type still genuinely implements proto.Message: ProtoReflect returns
+// a structMessage (kart_reflect.go) built from the field descriptors
+// kart_desc.go derives from proto/kart.proto, so encoding/grpc's wire
+// marshal/unmarshal works the same as it would against real generated code.
+package kartpb
+
+import "google.golang.org/protobuf/reflect/protoreflect"
+
+type Product struct {
+	Id       string  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name     string  `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Price    float32 `protobuf:"fixed32,3,opt,name=price,proto3" json:"price,omitempty"`
+	Category string  `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`
+}
+
+func (x *Product) Reset()         { *x = Product{} }
+func (x *Product) String() string { return protoimplString(x) }
+func (*Product) ProtoMessage()    {}
+func (x *Product) ProtoReflect() protoreflect.Message {
+	return newStructMessage(productDesc, x)
+}
+
+type ListProductsRequest struct{}
+
+func (x *ListProductsRequest) Reset()         { *x = ListProductsRequest{} }
+func (x *ListProductsRequest) String() string { return protoimplString(x) }
+func (*ListProductsRequest) ProtoMessage()    {}
+func (x *ListProductsRequest) ProtoReflect() protoreflect.Message {
+	return newStructMessage(listProductsRequestDesc, x)
+}
+
+type ListProductsResponse struct {
+	Products []*Product `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+}
+
+func (x *ListProductsResponse) Reset()         { *x = ListProductsResponse{} }
+func (x *ListProductsResponse) String() string { return protoimplString(x) }
+func (*ListProductsResponse) ProtoMessage()    {}
+func (x *ListProductsResponse) ProtoReflect() protoreflect.Message {
+	return newStructMessage(listProductsResponseDesc, x)
+}
+
+type GetProductRequest struct {
+	ProductId int64 `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+}
+
+func (x *GetProductRequest) Reset()         { *x = GetProductRequest{} }
+func (x *GetProductRequest) String() string { return protoimplString(x) }
+func (*GetProductRequest) ProtoMessage()    {}
+func (x *GetProductRequest) ProtoReflect() protoreflect.Message {
+	return newStructMessage(getProductRequestDesc, x)
+}
+
+type OrderItem struct {
+	ProductId string `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity  int32  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+}
+
+func (x *OrderItem) Reset()         { *x = OrderItem{} }
+func (x *OrderItem) String() string { return protoimplString(x) }
+func (*OrderItem) ProtoMessage()    {}
+func (x *OrderItem) ProtoReflect() protoreflect.Message {
+	return newStructMessage(orderItemDesc, x)
+}
+
+type PlaceOrderRequest struct {
+	Items      []*OrderItem `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	CouponCode string       `protobuf:"bytes,2,opt,name=coupon_code,json=couponCode,proto3" json:"coupon_code,omitempty"`
+}
+
+func (x *PlaceOrderRequest) Reset()         { *x = PlaceOrderRequest{} }
+func (x *PlaceOrderRequest) String() string { return protoimplString(x) }
+func (*PlaceOrderRequest) ProtoMessage()    {}
+func (x *PlaceOrderRequest) ProtoReflect() protoreflect.Message {
+	return newStructMessage(placeOrderRequestDesc, x)
+}
+
+type Order struct {
+	Id       string       `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Items    []*OrderItem `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	Products []*Product   `protobuf:"bytes,3,rep,name=products,proto3" json:"products,omitempty"`
+}
+
+func (x *Order) Reset()         { *x = Order{} }
+func (x *Order) String() string { return protoimplString(x) }
+func (*Order) ProtoMessage()    {}
+func (x *Order) ProtoReflect() protoreflect.Message {
+	return newStructMessage(orderDesc, x)
+}
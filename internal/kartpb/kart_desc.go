@@ -0,0 +1,153 @@
+package kartpb
+
+import (
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// kartFile is built directly from a descriptorpb.FileDescriptorProto literal
+// that mirrors proto/kart.proto field-for-field, instead of from the bytes
+// protoc would normally serialize. That keeps every message descriptor
+// below in protodesc/protoreflect, which is what lets the Go types in
+// kart.pb.go genuinely implement proto.Message (see ProtoReflect in that
+// file) without depending on the protoc toolchain being installed.
+//
+// If protoc becomes available, `make generate-proto` (see the Makefile)
+// replaces this file and kart.pb.go's reflection plumbing with real
+// protoc-gen-go/protoc-gen-go-grpc output; the message and service shapes
+// are kept in sync with proto/kart.proto by hand until then.
+var kartFile protoreflect.FileDescriptor
+
+var (
+	productDesc              protoreflect.MessageDescriptor
+	listProductsRequestDesc  protoreflect.MessageDescriptor
+	listProductsResponseDesc protoreflect.MessageDescriptor
+	getProductRequestDesc    protoreflect.MessageDescriptor
+	orderItemDesc            protoreflect.MessageDescriptor
+	placeOrderRequestDesc    protoreflect.MessageDescriptor
+	orderDesc                protoreflect.MessageDescriptor
+)
+
+func strPtr(s string) *string { return &s }
+func i32Ptr(v int32) *int32   { return &v }
+
+func scalarField(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type) *descriptorpb.FieldDescriptorProto {
+	return &descriptorpb.FieldDescriptorProto{
+		Name:     strPtr(name),
+		Number:   i32Ptr(number),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		Type:     typ.Enum(),
+		JsonName: strPtr(name),
+	}
+}
+
+func messageField(name string, number int32, repeated bool, typeName string) *descriptorpb.FieldDescriptorProto {
+	label := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL
+	if repeated {
+		label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+	}
+	return &descriptorpb.FieldDescriptorProto{
+		Name:     strPtr(name),
+		Number:   i32Ptr(number),
+		Label:    label.Enum(),
+		Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+		TypeName: strPtr(typeName),
+		JsonName: strPtr(name),
+	}
+}
+
+func init() {
+	fd := &descriptorpb.FileDescriptorProto{
+		Name:    strPtr("proto/kart.proto"),
+		Package: strPtr("kart"),
+		Syntax:  strPtr("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: strPtr("Product"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					scalarField("id", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+					scalarField("name", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+					scalarField("price", 3, descriptorpb.FieldDescriptorProto_TYPE_FLOAT),
+					scalarField("category", 4, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+				},
+			},
+			{Name: strPtr("ListProductsRequest")},
+			{
+				Name: strPtr("ListProductsResponse"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					messageField("products", 1, true, ".kart.Product"),
+				},
+			},
+			{
+				Name: strPtr("GetProductRequest"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					scalarField("product_id", 1, descriptorpb.FieldDescriptorProto_TYPE_INT64),
+				},
+			},
+			{
+				Name: strPtr("OrderItem"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					scalarField("product_id", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+					scalarField("quantity", 2, descriptorpb.FieldDescriptorProto_TYPE_INT32),
+				},
+			},
+			{
+				Name: strPtr("PlaceOrderRequest"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					messageField("items", 1, true, ".kart.OrderItem"),
+					scalarField("coupon_code", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+				},
+			},
+			{
+				Name: strPtr("Order"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					scalarField("id", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+					messageField("items", 2, true, ".kart.OrderItem"),
+					messageField("products", 3, true, ".kart.Product"),
+				},
+			},
+		},
+		Service: []*descriptorpb.ServiceDescriptorProto{
+			{
+				Name: strPtr("ProductService"),
+				Method: []*descriptorpb.MethodDescriptorProto{
+					{Name: strPtr("ListProducts"), InputType: strPtr(".kart.ListProductsRequest"), OutputType: strPtr(".kart.ListProductsResponse")},
+					{Name: strPtr("GetProduct"), InputType: strPtr(".kart.GetProductRequest"), OutputType: strPtr(".kart.Product")},
+				},
+			},
+			{
+				Name: strPtr("OrderService"),
+				Method: []*descriptorpb.MethodDescriptorProto{
+					{Name: strPtr("PlaceOrder"), InputType: strPtr(".kart.PlaceOrderRequest"), OutputType: strPtr(".kart.Order")},
+				},
+			},
+		},
+	}
+
+	f, err := protodesc.NewFile(fd, nil)
+	if err != nil {
+		panic("kartpb: building file descriptor: " + err.Error())
+	}
+	kartFile = f
+
+	msgs := f.Messages()
+	productDesc = msgs.ByName("Product")
+	listProductsRequestDesc = msgs.ByName("ListProductsRequest")
+	listProductsResponseDesc = msgs.ByName("ListProductsResponse")
+	getProductRequestDesc = msgs.ByName("GetProductRequest")
+	orderItemDesc = msgs.ByName("OrderItem")
+	placeOrderRequestDesc = msgs.ByName("PlaceOrderRequest")
+	orderDesc = msgs.ByName("Order")
+
+	// Field number -> Go struct field name, so structMessage (kart_reflect.go)
+	// can resolve a protoreflect.FieldDescriptor straight to the field the
+	// rest of this codebase already reads/writes on these structs.
+	registerFields(productDesc, map[protoreflect.FieldNumber]string{1: "Id", 2: "Name", 3: "Price", 4: "Category"})
+	registerFields(listProductsRequestDesc, map[protoreflect.FieldNumber]string{})
+	registerFields(listProductsResponseDesc, map[protoreflect.FieldNumber]string{1: "Products"})
+	registerFields(getProductRequestDesc, map[protoreflect.FieldNumber]string{1: "ProductId"})
+	registerFields(orderItemDesc, map[protoreflect.FieldNumber]string{1: "ProductId", 2: "Quantity"})
+	registerFields(placeOrderRequestDesc, map[protoreflect.FieldNumber]string{1: "Items", 2: "CouponCode"})
+	registerFields(orderDesc, map[protoreflect.FieldNumber]string{1: "Id", 2: "Items", 3: "Products"})
+}
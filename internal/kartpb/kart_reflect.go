@@ -0,0 +1,235 @@
+package kartpb
+
+import (
+	"reflect"
+
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/runtime/protoiface"
+)
+
+// protoimplString renders m the way protoc-gen-go's String() methods do
+// (protoimpl.X.MessageStringOf), without depending on protoimpl's
+// TypeBuilder-oriented MessageState - structMessage isn't built that way.
+func protoimplString(m protoreflect.ProtoMessage) string {
+	return prototext.MarshalOptions{Multiline: false}.Format(m)
+}
+
+// fieldGoNames maps each message descriptor to {field number -> Go struct
+// field name}, so structMessage can resolve a protoreflect.FieldDescriptor
+// straight to the same struct field grpcserver/server.go already reads and
+// writes - there is exactly one copy of the data.
+var fieldGoNames = map[protoreflect.MessageDescriptor]map[protoreflect.FieldNumber]string{}
+
+func registerFields(desc protoreflect.MessageDescriptor, names map[protoreflect.FieldNumber]string) {
+	fieldGoNames[desc] = names
+}
+
+// structMessage is a generic protoreflect.Message over one of this
+// package's plain structs (Product, Order, ...). It implements the full
+// interface (rather than opting into proto.Marshal/Unmarshal's fast path
+// via ProtoMethods) so it works correctly without any protoc-generated
+// fast-path glue - see kart_desc.go for why this exists instead of
+// protoc-gen-go output.
+type structMessage struct {
+	desc protoreflect.MessageDescriptor
+	val  reflect.Value // addressable struct value
+}
+
+func newStructMessage(desc protoreflect.MessageDescriptor, ptr protoreflect.ProtoMessage) protoreflect.Message {
+	return &structMessage{desc: desc, val: reflect.ValueOf(ptr).Elem()}
+}
+
+func (m *structMessage) goField(fd protoreflect.FieldDescriptor) reflect.Value {
+	name, ok := fieldGoNames[m.desc][fd.Number()]
+	if !ok {
+		panic("kartpb: no Go field registered for " + string(fd.FullName()))
+	}
+	return m.val.FieldByName(name)
+}
+
+func (m *structMessage) Descriptor() protoreflect.MessageDescriptor { return m.desc }
+
+func (m *structMessage) Type() protoreflect.MessageType {
+	return structMessageType{desc: m.desc, goType: m.val.Type()}
+}
+
+func (m *structMessage) New() protoreflect.Message {
+	return m.Type().New()
+}
+
+func (m *structMessage) Interface() protoreflect.ProtoMessage {
+	return m.val.Addr().Interface().(protoreflect.ProtoMessage)
+}
+
+func (m *structMessage) Range(f func(protoreflect.FieldDescriptor, protoreflect.Value) bool) {
+	fields := m.desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if m.Has(fd) && !f(fd, m.Get(fd)) {
+			return
+		}
+	}
+}
+
+func (m *structMessage) Has(fd protoreflect.FieldDescriptor) bool {
+	f := m.goField(fd)
+	switch {
+	case fd.IsList():
+		return f.Len() > 0
+	case fd.Kind() == protoreflect.MessageKind:
+		return !f.IsNil()
+	default:
+		return !f.IsZero()
+	}
+}
+
+func (m *structMessage) Clear(fd protoreflect.FieldDescriptor) {
+	f := m.goField(fd)
+	f.Set(reflect.Zero(f.Type()))
+}
+
+func (m *structMessage) Get(fd protoreflect.FieldDescriptor) protoreflect.Value {
+	f := m.goField(fd)
+	if fd.IsList() {
+		return protoreflect.ValueOfList(&messageList{val: f})
+	}
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(f.String())
+	case protoreflect.Int32Kind:
+		return protoreflect.ValueOfInt32(int32(f.Int()))
+	case protoreflect.Int64Kind:
+		return protoreflect.ValueOfInt64(f.Int())
+	case protoreflect.FloatKind:
+		return protoreflect.ValueOfFloat32(float32(f.Float()))
+	case protoreflect.MessageKind:
+		if f.IsNil() {
+			return protoreflect.ValueOfMessage(zeroMessage(f.Type()))
+		}
+		return protoreflect.ValueOfMessage(f.Interface().(protoreflect.ProtoMessage).ProtoReflect())
+	default:
+		panic("kartpb: unsupported field kind " + fd.Kind().String())
+	}
+}
+
+func (m *structMessage) Set(fd protoreflect.FieldDescriptor, v protoreflect.Value) {
+	f := m.goField(fd)
+	if fd.IsList() {
+		f.Set(v.List().(*messageList).val)
+		return
+	}
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		f.SetString(v.String())
+	case protoreflect.Int32Kind:
+		f.SetInt(v.Int())
+	case protoreflect.Int64Kind:
+		f.SetInt(v.Int())
+	case protoreflect.FloatKind:
+		f.SetFloat(float64(v.Float()))
+	case protoreflect.MessageKind:
+		f.Set(reflect.ValueOf(v.Message().Interface()))
+	default:
+		panic("kartpb: unsupported field kind " + fd.Kind().String())
+	}
+}
+
+func (m *structMessage) Mutable(fd protoreflect.FieldDescriptor) protoreflect.Value {
+	f := m.goField(fd)
+	if fd.IsList() {
+		return protoreflect.ValueOfList(&messageList{val: f})
+	}
+	if fd.Kind() != protoreflect.MessageKind {
+		panic("kartpb: Mutable called on a non-composite field")
+	}
+	if f.IsNil() {
+		f.Set(reflect.New(f.Type().Elem()))
+	}
+	return protoreflect.ValueOfMessage(f.Interface().(protoreflect.ProtoMessage).ProtoReflect())
+}
+
+func (m *structMessage) NewField(fd protoreflect.FieldDescriptor) protoreflect.Value {
+	if fd.IsList() {
+		return protoreflect.ValueOfList(&messageList{val: reflect.New(m.goField(fd).Type()).Elem()})
+	}
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString("")
+	case protoreflect.Int32Kind:
+		return protoreflect.ValueOfInt32(0)
+	case protoreflect.Int64Kind:
+		return protoreflect.ValueOfInt64(0)
+	case protoreflect.FloatKind:
+		return protoreflect.ValueOfFloat32(0)
+	case protoreflect.MessageKind:
+		return protoreflect.ValueOfMessage(zeroMessage(m.goField(fd).Type()))
+	default:
+		panic("kartpb: unsupported field kind " + fd.Kind().String())
+	}
+}
+
+func (m *structMessage) WhichOneof(protoreflect.OneofDescriptor) protoreflect.FieldDescriptor { return nil }
+func (m *structMessage) GetUnknown() protoreflect.RawFields                                   { return nil }
+func (m *structMessage) SetUnknown(protoreflect.RawFields)                                    {}
+func (m *structMessage) IsValid() bool                                                        { return m.val.IsValid() }
+func (m *structMessage) ProtoMethods() *protoiface.Methods                                     { return nil }
+
+// zeroMessage builds a fresh zero-valued message for a *T field type (e.g.
+// *Product), used where proto3 message-kind fields need a read-only default
+// (Get on an unset field) or a blank value to merge into (NewField).
+func zeroMessage(ptrType reflect.Type) protoreflect.Message {
+	zero := reflect.New(ptrType.Elem())
+	return zero.Interface().(protoreflect.ProtoMessage).ProtoReflect()
+}
+
+// structMessageType is the protoreflect.MessageType for a structMessage.
+type structMessageType struct {
+	desc   protoreflect.MessageDescriptor
+	goType reflect.Type // struct type (not pointer)
+}
+
+func (t structMessageType) New() protoreflect.Message {
+	zero := reflect.New(t.goType)
+	return zero.Interface().(protoreflect.ProtoMessage).ProtoReflect()
+}
+func (t structMessageType) Zero() protoreflect.Message                { return t.New() }
+func (t structMessageType) Descriptor() protoreflect.MessageDescriptor { return t.desc }
+
+// messageList is a generic protoreflect.List over a []*T struct field
+// (e.g. []*Product, []*OrderItem).
+type messageList struct {
+	val reflect.Value // addressable slice value
+}
+
+func (l *messageList) Len() int { return l.val.Len() }
+
+func (l *messageList) Get(i int) protoreflect.Value {
+	pm := l.val.Index(i).Interface().(protoreflect.ProtoMessage)
+	return protoreflect.ValueOfMessage(pm.ProtoReflect())
+}
+
+func (l *messageList) Set(i int, v protoreflect.Value) {
+	l.val.Index(i).Set(reflect.ValueOf(v.Message().Interface()))
+}
+
+func (l *messageList) Append(v protoreflect.Value) {
+	l.val.Set(reflect.Append(l.val, reflect.ValueOf(v.Message().Interface())))
+}
+
+func (l *messageList) AppendMutable() protoreflect.Value {
+	elem := reflect.New(l.val.Type().Elem().Elem())
+	l.val.Set(reflect.Append(l.val, elem))
+	pm := elem.Interface().(protoreflect.ProtoMessage)
+	return protoreflect.ValueOfMessage(pm.ProtoReflect())
+}
+
+func (l *messageList) Truncate(n int) { l.val.Set(l.val.Slice(0, n)) }
+
+func (l *messageList) NewElement() protoreflect.Value {
+	elem := reflect.New(l.val.Type().Elem().Elem())
+	pm := elem.Interface().(protoreflect.ProtoMessage)
+	return protoreflect.ValueOfMessage(pm.ProtoReflect())
+}
+
+func (l *messageList) IsValid() bool { return l.val.IsValid() }
@@ -0,0 +1,88 @@
+package kartpb
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// TestPlaceOrderRequestRoundTrip exercises the hand-rolled structMessage /
+// messageList reflection bridge (kart_reflect.go) through the real
+// google.golang.org/protobuf/proto package, not just this package's own
+// accessors - proto.Marshal/Unmarshal drive Range/Has/Get on the way out
+// and Mutable/Set plus messageList's NewElement/Append on the way in,
+// covering both repeated and nested message fields.
+func TestPlaceOrderRequestRoundTrip(t *testing.T) {
+	want := &PlaceOrderRequest{
+		Items: []*OrderItem{
+			{ProductId: "p1", Quantity: 2},
+			{ProductId: "p2", Quantity: 1},
+		},
+		CouponCode: "SAVE10",
+	}
+
+	data, err := proto.Marshal(want)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	got := &PlaceOrderRequest{}
+	if err := proto.Unmarshal(data, got); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	if !proto.Equal(want, got) {
+		t.Fatalf("round trip mismatch:\n want %+v\n got  %+v", want, got)
+	}
+}
+
+// TestOrderRoundTrip covers a second message shape with two distinct
+// repeated message fields (Items and Products) to make sure messageList
+// isn't accidentally aliasing state between fields.
+func TestOrderRoundTrip(t *testing.T) {
+	want := &Order{
+		Id: "order-1",
+		Items: []*OrderItem{
+			{ProductId: "p1", Quantity: 3},
+		},
+		Products: []*Product{
+			{Id: "p1", Name: "Widget", Price: 9.99, Category: "tools"},
+			{Id: "p2", Name: "Gadget", Price: 19.99, Category: "electronics"},
+		},
+	}
+
+	data, err := proto.Marshal(want)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	got := &Order{}
+	if err := proto.Unmarshal(data, got); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	if !proto.Equal(want, got) {
+		t.Fatalf("round trip mismatch:\n want %+v\n got  %+v", want, got)
+	}
+}
+
+// TestOrderRoundTripEmptyRepeatedFields makes sure an order with no items
+// or products round-trips to nil/empty slices rather than a wire panic,
+// since Has() treats a zero-length slice as absent.
+func TestOrderRoundTripEmptyRepeatedFields(t *testing.T) {
+	want := &Order{Id: "order-empty"}
+
+	data, err := proto.Marshal(want)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	got := &Order{}
+	if err := proto.Unmarshal(data, got); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	if !proto.Equal(want, got) {
+		t.Fatalf("round trip mismatch:\n want %+v\n got  %+v", want, got)
+	}
+}
@@ -0,0 +1,78 @@
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+
+	"github.com/gin-gonic/gin"
+)
+
+// reloadStatsDTO mirrors coupons.ReloadStats for JSON encoding: ReloadStats.Err
+// is a plain `error`, whose unexported fields json.Marshal can't see.
+type reloadStatsDTO struct {
+	File        string `json:"file"`
+	BytesRead   int64  `json:"bytesRead"`
+	LinesParsed int64  `json:"linesParsed"`
+	UniqueCodes int64  `json:"uniqueCodes"`
+	ElapsedMs   int64  `json:"elapsedMs"`
+	Err         string `json:"err,omitempty"`
+}
+
+// RegisterDebugRoutes wires the coupon-reload observability endpoint. Not
+// part of the generated api.ServerInterface, so it's registered directly
+// like RegisterLifecycleRoutes.
+func (s *ServerImpl) RegisterDebugRoutes(r *gin.Engine) {
+	r.GET("/debug/coupons/reload", s.StreamCouponReload)
+}
+
+// StreamCouponReload triggers a coupon reload and streams its ReloadStats as
+// Server-Sent Events, so an operator can watch progress live on the
+// multi-GB coupon files instead of only seeing the final log line.
+func (s *ServerImpl) StreamCouponReload(c *gin.Context) {
+	updates := s.Coupons.Subscribe()
+	defer s.Coupons.Unsubscribe(updates)
+
+	// ReloadNow's error is also delivered as the terminal ReloadStats.Err on
+	// updates (see reload_progress.go's finish()), which the Stream loop
+	// below renders. Just log it here - this goroutine must never touch c,
+	// since it can outlive the handler and gin recycles *gin.Context once
+	// c.Stream returns.
+	reqCtx := c.Request.Context()
+	go func() {
+		if err := s.Coupons.ReloadNow(reqCtx); err != nil {
+			log.Printf("[debug] coupon reload failed: %v", err)
+		}
+	}()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case stats, ok := <-updates:
+			if !ok {
+				return false
+			}
+			dto := reloadStatsDTO{
+				File:        stats.File,
+				BytesRead:   stats.BytesRead,
+				LinesParsed: stats.LinesParsed,
+				UniqueCodes: stats.UniqueCodes,
+				ElapsedMs:   stats.Elapsed.Milliseconds(),
+			}
+			if stats.Err != nil {
+				dto.Err = stats.Err.Error()
+			}
+			payload, err := json.Marshal(dto)
+			if err != nil {
+				return false
+			}
+			_, werr := w.Write(append(append([]byte("data: "), payload...), '\n', '\n'))
+			return werr == nil
+		case <-c.Request.Context().Done():
+			return false
+		}
+	})
+}
@@ -1,6 +1,8 @@
 package server
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
@@ -14,13 +16,13 @@ import (
 
 // ServerImpl implements api.ServerInterface (Gin-based).
 type ServerImpl struct {
-	Store   *store.InMemoryStore
+	Store   store.Store
 	Coupons *coupons.Manager
 	APIKey  string
 }
 
 // NewServerImpl constructs the service implementation.
-func NewServerImpl(st *store.InMemoryStore, mgr *coupons.Manager) *ServerImpl {
+func NewServerImpl(st store.Store, mgr *coupons.Manager) *ServerImpl {
 	return &ServerImpl{
 		Store:   st,
 		Coupons: mgr,
@@ -40,7 +42,11 @@ func writeAPIError(c *gin.Context, httpStatus int, code int, typ string, message
 // ListProducts handles GET /product
 // Signature matches api.ServerInterface: ListProducts(c *gin.Context)
 func (s *ServerImpl) ListProducts(c *gin.Context) {
-	prods := s.Store.ListProducts()
+	prods, err := s.Store.ListProducts()
+	if err != nil {
+		writeAPIError(c, http.StatusInternalServerError, 500, "error", "failed to list products: "+err.Error())
+		return
+	}
 
 	// convert []store.Product -> []api.Product (with pointer fields)
 	out := make([]api.Product, 0, len(prods))
@@ -65,7 +71,11 @@ func (s *ServerImpl) ListProducts(c *gin.Context) {
 func (s *ServerImpl) GetProduct(c *gin.Context, productId int64) {
 	// convert int64 to string because store keys are strings like "10"
 	idStr := strconv.FormatInt(productId, 10)
-	p, ok := s.Store.GetProductByID(idStr)
+	p, ok, err := s.Store.GetProductByID(idStr)
+	if err != nil {
+		writeAPIError(c, http.StatusInternalServerError, 500, "error", "failed to get product: "+err.Error())
+		return
+	}
 	if !ok {
 		// spec expects 404 when not found
 		c.String(http.StatusNotFound, "product not found")
@@ -122,7 +132,16 @@ func (s *ServerImpl) PlaceOrder(c *gin.Context) {
 
 	// coupon validation if provided
 	if req.CouponCode != nil && strings.TrimSpace(*req.CouponCode) != "" {
-		if !s.Coupons.IsValidPromo(*req.CouponCode) {
+		valid, err := s.Coupons.IsValidPromo(c.Request.Context(), *req.CouponCode)
+		if err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
+				writeAPIError(c, http.StatusGatewayTimeout, 504, "error", "coupon lookup timed out")
+				return
+			}
+			writeAPIError(c, http.StatusInternalServerError, 500, "error", "coupon lookup failed: "+err.Error())
+			return
+		}
+		if !valid {
 			writeAPIError(c, http.StatusUnprocessableEntity, 422, "validation_error", "invalid coupon code")
 			return
 		}
@@ -178,3 +197,103 @@ func (s *ServerImpl) PlaceOrder(c *gin.Context) {
 
 	c.JSON(http.StatusOK, apiOrder)
 }
+
+// -----------------------------------------------------------------------------
+// Order lifecycle: PATCH /order/{id}, POST /order/{id}/cancel, GET /order/{id}/history
+// -----------------------------------------------------------------------------
+
+// RegisterLifecycleRoutes wires the order lifecycle endpoints onto r.
+// api/openapi.lifecycle.yaml describes these paths for codegen; until it's
+// merged into the project's main spec and regenerated into
+// api.ServerInterface, they're registered directly alongside
+// api.RegisterHandlers. The handler signatures below already match the
+// convention api.ServerInterface methods use for path parameters (see
+// GetProduct), so folding these in later is a signature-compatible move,
+// not a rewrite.
+func (s *ServerImpl) RegisterLifecycleRoutes(r *gin.Engine) {
+	r.PATCH("/order/:id", func(c *gin.Context) { s.UpdateOrderStatus(c, c.Param("id")) })
+	r.POST("/order/:id/cancel", func(c *gin.Context) { s.CancelOrder(c, c.Param("id")) })
+	r.GET("/order/:id/history", func(c *gin.Context) { s.GetOrderHistory(c, c.Param("id")) })
+}
+
+// writeOrderStoreError maps store order-lifecycle errors onto the shared
+// ApiResponse error shape: 404 for unknown ids, 409 for illegal transitions.
+func writeOrderStoreError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, store.ErrOrderNotFound):
+		writeAPIError(c, http.StatusNotFound, 404, "error", "order not found")
+	case errors.Is(err, store.ErrIllegalTransition):
+		writeAPIError(c, http.StatusConflict, 409, "error", err.Error())
+	default:
+		writeAPIError(c, http.StatusBadRequest, 400, "error", err.Error())
+	}
+}
+
+type updateOrderStatusReq struct {
+	Status string `json:"status"`
+}
+
+// UpdateOrderStatus handles PATCH /order/{id}.
+// Signature matches api.ServerInterface convention: UpdateOrderStatus(c *gin.Context, id string)
+func (s *ServerImpl) UpdateOrderStatus(c *gin.Context, id string) {
+	var req updateOrderStatusReq
+	if err := c.ShouldBindJSON(&req); err != nil || strings.TrimSpace(req.Status) == "" {
+		writeAPIError(c, http.StatusBadRequest, 400, "error", "invalid JSON body: status is required")
+		return
+	}
+	to := store.OrderStatus(strings.ToLower(strings.TrimSpace(req.Status)))
+
+	order, ok, err := s.Store.GetOrderByID(id)
+	if err != nil {
+		writeAPIError(c, http.StatusInternalServerError, 500, "error", "failed to get order: "+err.Error())
+		return
+	}
+	if !ok {
+		writeAPIError(c, http.StatusNotFound, 404, "error", "order not found")
+		return
+	}
+
+	if err := s.Store.UpdateOrderStatus(id, order.Status, to); err != nil {
+		writeOrderStoreError(c, err)
+		return
+	}
+
+	updated, _, _ := s.Store.GetOrderByID(id)
+	c.JSON(http.StatusOK, updated)
+}
+
+type cancelOrderReq struct {
+	Reason string `json:"reason"`
+}
+
+// CancelOrder handles POST /order/{id}/cancel.
+// Signature matches api.ServerInterface convention: CancelOrder(c *gin.Context, id string)
+func (s *ServerImpl) CancelOrder(c *gin.Context, id string) {
+	var req cancelOrderReq
+	// Reason is optional, so ignore a missing/empty body.
+	_ = c.ShouldBindJSON(&req)
+
+	if err := s.Store.CancelOrder(id, req.Reason); err != nil {
+		writeOrderStoreError(c, err)
+		return
+	}
+
+	updated, _, _ := s.Store.GetOrderByID(id)
+	c.JSON(http.StatusOK, updated)
+}
+
+// GetOrderHistory handles GET /order/{id}/history.
+// Signature matches api.ServerInterface convention: GetOrderHistory(c *gin.Context, id string)
+func (s *ServerImpl) GetOrderHistory(c *gin.Context, id string) {
+	order, ok, err := s.Store.GetOrderByID(id)
+	if err != nil {
+		writeAPIError(c, http.StatusInternalServerError, 500, "error", "failed to get order: "+err.Error())
+		return
+	}
+	if !ok {
+		writeAPIError(c, http.StatusNotFound, 404, "error", "order not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, order.History)
+}